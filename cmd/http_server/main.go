@@ -8,19 +8,43 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
+	"github.com/kurushqosimi/rocket_factory/pkg/auth"
 	"github.com/kurushqosimi/rocket_factory/pkg/models"
+	weathermw "github.com/kurushqosimi/rocket_factory/pkg/middleware"
+	"github.com/kurushqosimi/rocket_factory/pkg/poller"
+	"github.com/kurushqosimi/rocket_factory/pkg/provider"
+	"github.com/kurushqosimi/rocket_factory/pkg/timeseries"
 	"log"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 )
 
 const (
-	httpPort     = "8080"
-	urlParamCity = "city"
+	httpPort             = "8080"
+	urlParamCity         = "city"
+	queryMaxAge          = "max_age"
+	queryFrom            = "from"
+	queryTo              = "to"
+	queryStep            = "step"
+	queryFill            = "fill"
+	queryHours           = "hours"
+	envCacheFile         = "WEATHER_CACHE_FILE"
+	envWeatherTTL        = "WEATHER_TTL"
+	envTrackedCitiesFile = "POLLER_TRACKED_CITIES_FILE"
+	envHistoryCapacity   = "WEATHER_HISTORY_CAPACITY"
+
+	defaultForecastHours = 24
+
+	// minHistoryStep and maxHistoryBuckets bound the work historyHandler can
+	// ask Downsample to do, so a client can't force an effectively unbounded
+	// number of buckets via a tiny ?step= over a wide ?from=/?to= range.
+	minHistoryStep    = time.Second
+	maxHistoryBuckets = 10000
 
 	readHeaderTimeout = 5 * time.Second
 	shutdownTimeout   = 10 * time.Second
@@ -28,7 +52,30 @@ const (
 
 func main() {
 	// storage init
-	storage := models.NewWeatherStorage()
+	storage := models.NewWeatherStorage(storageOptionsFromEnv()...)
+	defer func() {
+		if err := storage.Close(); err != nil {
+			log.Printf("Error closing weather storage: %v\n", err)
+		}
+	}()
+
+	// upstream weather provider init, used as a fallback on storage misses
+	weatherProvider := newWeatherProvider()
+
+	// rate limiting and response caching init
+	rateLimitCfg := weathermw.LoadRateLimiterConfigFromEnv()
+	responseCacheCfg := weathermw.LoadResponseCacheConfigFromEnv()
+	responseCache := weathermw.NewResponseCache(responseCacheCfg)
+
+	// tracked cities shared with cmd/poller, reloadable via PUT /api/v1/tracked
+	trackedCities := poller.NewTrackedCities(nil, os.Getenv(envTrackedCitiesFile))
+
+	// auth init
+	authCfg := auth.LoadConfigFromEnv()
+	authVerifier := newAuthVerifier(authCfg)
+
+	// forecast results cache
+	forecastCache := provider.NewForecastCache(0, 0)
 
 	// router initialization
 	r := chi.NewRouter()
@@ -39,12 +86,46 @@ func main() {
 	r.Use(middleware.Timeout(10 * time.Second))
 	r.Use(render.SetContentType(render.ContentTypeJSON))
 
+	if rateLimitCfg.Enabled {
+		r.Use(weathermw.RateLimit(weathermw.NewRateLimiter(rateLimitCfg)))
+	}
+
 	// routes
 	r.Route("/api/v1/weather", func(r chi.Router) {
-		r.Get("/{city}", getWeatherHandler(storage))
-		r.Put("/{city}", updateWeatherHandler(storage))
+		getRouter := r
+		if authCfg.Enabled && authCfg.RequireAuthOnReads {
+			getRouter = r.With(auth.RequireAuth(authVerifier))
+		}
+
+		getRouter.Get("/", listWeatherHandler(storage))
+		getRouter.Get("/stream", streamWeatherHandler(storage))
+		getRouter.Get("/{city}/history", historyHandler(storage))
+		getRouter.Get("/{city}/forecast", forecastHandler(weatherProvider, forecastCache))
+
+		if responseCacheCfg.Enabled {
+			getRouter.With(weathermw.CacheGet(responseCache, responseCacheCfg.RefreshKey)).Get("/{city}", getWeatherHandler(storage, weatherProvider))
+		} else {
+			getRouter.Get("/{city}", getWeatherHandler(storage, weatherProvider))
+		}
+
+		putRouter := r
+		if authCfg.Enabled {
+			putRouter = r.With(auth.RequireRole(authVerifier, auth.RoleWriter))
+		}
+
+		putRouter.Put("/{city}", updateWeatherHandler(storage, responseCache))
 	})
 
+	if authCfg.Enabled {
+		r.With(auth.RequireRole(authVerifier, auth.RoleWriter)).Put("/api/v1/tracked", updateTrackedCitiesHandler(trackedCities))
+	} else {
+		r.Put("/api/v1/tracked", updateTrackedCitiesHandler(trackedCities))
+	}
+
+	if tokenHandler := newTokenHandler(authCfg); tokenHandler != nil {
+		r.Post("/api/v1/auth/token", tokenHandler)
+	}
+
 	// HTTP server start
 	server := &http.Server{
 		Addr:              net.JoinHostPort("localhost", httpPort),
@@ -80,8 +161,82 @@ func main() {
 	log.Println("Server stopped")
 }
 
+// newWeatherProvider builds the upstream WeatherProvider from environment
+// configuration, or returns nil if no API key is configured.
+func newWeatherProvider() provider.WeatherProvider {
+	cfg := provider.LoadConfigFromEnv()
+	if !cfg.Enabled() {
+		log.Println("No upstream weather provider configured, falling back to storage only")
+		return nil
+	}
+
+	return provider.NewOpenWeatherMapProvider(cfg)
+}
+
+// newAuthVerifier builds a Verifier from cfg, preferring the JWKS URL over
+// the HMAC secret when both are configured. Returns nil when auth is disabled.
+func newAuthVerifier(cfg auth.Config) auth.Verifier {
+	switch {
+	case cfg.JWKSURL != "":
+		return auth.NewJWKSVerifier(cfg.JWKSURL)
+	case len(cfg.HMACSecret) > 0:
+		return auth.NewHMACVerifier(cfg.HMACSecret)
+	default:
+		return nil
+	}
+}
+
+// newTokenHandler wires up the /api/v1/auth/token endpoint when both an
+// HMAC secret (to sign issued tokens) and an htpasswd file (to check
+// credentials against) are configured.
+func newTokenHandler(cfg auth.Config) http.HandlerFunc {
+	if len(cfg.HMACSecret) == 0 || cfg.HtpasswdFile == "" {
+		return nil
+	}
+
+	creds, err := auth.LoadHtpasswd(cfg.HtpasswdFile)
+	if err != nil {
+		log.Printf("Failed to load htpasswd file, auth token endpoint disabled: %v\n", err)
+		return nil
+	}
+
+	issuer := auth.NewIssuer(cfg.HMACSecret, cfg.TokenTTL)
+
+	return auth.TokenHandler(issuer, creds)
+}
+
+// storageOptionsFromEnv builds WeatherStorage options from environment
+// variables, so the cache location and TTL can be tuned without code changes.
+func storageOptionsFromEnv() []models.Option {
+	var opts []models.Option
+
+	if path := os.Getenv(envCacheFile); path != "" {
+		opts = append(opts, models.WithCacheLocation(path))
+	}
+
+	if raw := os.Getenv(envWeatherTTL); raw != "" {
+		if ttl, err := time.ParseDuration(raw); err == nil {
+			opts = append(opts, models.WithTTL(ttl))
+		} else {
+			log.Printf("Invalid %s value %q: %v\n", envWeatherTTL, raw, err)
+		}
+	}
+
+	capacity := timeseries.DefaultCapacity
+	if raw := os.Getenv(envHistoryCapacity); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil {
+			capacity = n
+		} else {
+			log.Printf("Invalid %s value %q: %v\n", envHistoryCapacity, raw, err)
+		}
+	}
+	opts = append(opts, models.WithTimeSeriesStore(timeseries.NewRingStore(capacity)))
+
+	return opts
+}
+
 // getWeatherHandler processes requests for getting information about weather for the specific city
-func getWeatherHandler(storage *models.WeatherStorage) http.HandlerFunc {
+func getWeatherHandler(storage *models.WeatherStorage, weatherProvider provider.WeatherProvider) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		city := chi.URLParam(r, urlParamCity)
 		if city == "" {
@@ -89,18 +244,278 @@ func getWeatherHandler(storage *models.WeatherStorage) http.HandlerFunc {
 			return
 		}
 
-		weather := storage.GetWeather(city)
-		if weather == nil {
-			http.Error(w, fmt.Sprintf("Weather for city '%s' not found", city), http.StatusNotFound)
+		maxAge, err := parseMaxAge(r)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid %s parameter: %v", queryMaxAge, err), http.StatusBadRequest)
 			return
 		}
 
+		weather, stale := storage.GetWeather(city, maxAge)
+		if weather == nil || stale {
+			if weatherProvider == nil {
+				if weather == nil {
+					http.Error(w, fmt.Sprintf("Weather for city '%s' not found", city), http.StatusNotFound)
+					return
+				}
+
+				http.Error(w, fmt.Sprintf("Cached weather for city '%s' is stale and no upstream provider is configured", city), http.StatusGatewayTimeout)
+				return
+			}
+
+			fetched, err := weatherProvider.FetchWeather(r.Context(), city)
+			if err != nil {
+				log.Printf("fetch weather from upstream provider for city %q: %v\n", city, err)
+
+				if weather == nil {
+					http.Error(w, fmt.Sprintf("Weather for city '%s' not found", city), http.StatusNotFound)
+					return
+				}
+
+				http.Error(w, fmt.Sprintf("Cached weather for city '%s' is stale and the upstream refresh failed", city), http.StatusGatewayTimeout)
+				return
+			}
+
+			storage.UpdateWeather(fetched)
+			weather = fetched
+		}
+
 		render.JSON(w, r, weather)
 	}
 }
 
+// listWeatherHandler streams every cached Weather as newline-delimited
+// JSON, one object per line, so clients can process large storages without
+// buffering the whole response.
+func listWeatherHandler(storage *models.WeatherStorage) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Transfer-Encoding", "chunked")
+
+		enc := json.NewEncoder(w)
+		for _, weather := range storage.All() {
+			if err := enc.Encode(weather); err != nil {
+				log.Printf("encode weather for streaming list: %v\n", err)
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// streamWeatherHandler pushes a Server-Sent Event every time UpdateWeather
+// is called, optionally filtered to a single city via ?city=.
+func streamWeatherHandler(storage *models.WeatherStorage) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		cityFilter := r.URL.Query().Get(urlParamCity)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		updates := make(chan *models.Weather, 16)
+		unsubscribe := storage.Subscribe(updates)
+		defer unsubscribe()
+
+		for {
+			select {
+			case weather := <-updates:
+				if cityFilter != "" && weather.City != cityFilter {
+					continue
+				}
+
+				data, err := json.Marshal(weather)
+				if err != nil {
+					log.Printf("encode weather for SSE stream: %v\n", err)
+					continue
+				}
+
+				fmt.Fprintf(w, "event: weather\ndata: %s\n\n", data)
+				flusher.Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}
+}
+
+// historyHandler returns the recorded history for a city within [from, to],
+// optionally downsampled into mean/min/max buckets of width step.
+func historyHandler(storage *models.WeatherStorage) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		city := chi.URLParam(r, urlParamCity)
+		if city == "" {
+			http.Error(w, "City parameter is required", http.StatusBadRequest)
+			return
+		}
+
+		store := storage.TimeSeries()
+		if store == nil {
+			http.Error(w, "History is not available", http.StatusNotImplemented)
+			return
+		}
+
+		to := time.Now()
+		if raw := r.URL.Query().Get(queryTo); raw != "" {
+			parsed, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Invalid %s parameter: %v", queryTo, err), http.StatusBadRequest)
+				return
+			}
+			to = parsed
+		}
+
+		from := to.Add(-24 * time.Hour)
+		if raw := r.URL.Query().Get(queryFrom); raw != "" {
+			parsed, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Invalid %s parameter: %v", queryFrom, err), http.StatusBadRequest)
+				return
+			}
+			from = parsed
+		}
+
+		samples, err := store.Query(city, from, to)
+		if err != nil {
+			log.Printf("query history for city %q: %v\n", city, err)
+			http.Error(w, "Failed to query history", http.StatusInternalServerError)
+			return
+		}
+
+		step := r.URL.Query().Get(queryStep)
+		if step == "" {
+			render.JSON(w, r, samples)
+			return
+		}
+
+		stepDuration, err := time.ParseDuration(step)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid %s parameter: %v", queryStep, err), http.StatusBadRequest)
+			return
+		}
+
+		if stepDuration < minHistoryStep {
+			http.Error(w, fmt.Sprintf("%s must be at least %s", queryStep, minHistoryStep), http.StatusBadRequest)
+			return
+		}
+
+		if span := to.Sub(from); span > 0 {
+			buckets := span / stepDuration
+			if span%stepDuration != 0 {
+				buckets++
+			}
+			if buckets > maxHistoryBuckets {
+				http.Error(w, fmt.Sprintf("%s/%s range produces too many buckets (max %d)", queryFrom, queryTo, maxHistoryBuckets), http.StatusBadRequest)
+				return
+			}
+		}
+
+		carryForward := r.URL.Query().Get(queryFill) == "previous"
+
+		render.JSON(w, r, timeseries.Downsample(samples, from, to, stepDuration, carryForward))
+	}
+}
+
+// forecastHandler proxies to the upstream provider's forecast endpoint,
+// caching the result, when the configured provider supports forecasts.
+func forecastHandler(weatherProvider provider.WeatherProvider, cache *provider.ForecastCache) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		city := chi.URLParam(r, urlParamCity)
+		if city == "" {
+			http.Error(w, "City parameter is required", http.StatusBadRequest)
+			return
+		}
+
+		forecaster, ok := weatherProvider.(provider.ForecastProvider)
+		if weatherProvider == nil || !ok {
+			http.Error(w, "Forecast is not available without a configured upstream provider", http.StatusNotImplemented)
+			return
+		}
+
+		hours := defaultForecastHours
+		if raw := r.URL.Query().Get(queryHours); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Invalid %s parameter: %v", queryHours, err), http.StatusBadRequest)
+				return
+			}
+			hours = parsed
+		}
+
+		cacheKey := fmt.Sprintf("%s:%d", city, hours)
+
+		if cached, ok := cache.Get(cacheKey); ok {
+			render.JSON(w, r, cached)
+			return
+		}
+
+		forecast, err := forecaster.FetchForecast(r.Context(), city, hours)
+		if err != nil {
+			log.Printf("fetch forecast from upstream provider for city %q: %v\n", city, err)
+			http.Error(w, fmt.Sprintf("Failed to fetch forecast for city '%s'", city), http.StatusBadGateway)
+			return
+		}
+
+		cache.Set(cacheKey, forecast)
+
+		render.JSON(w, r, forecast)
+	}
+}
+
+// parseMaxAge extracts the optional ?max_age= query parameter as a duration.
+// A missing or empty parameter means "use the storage's default TTL".
+func parseMaxAge(r *http.Request) (time.Duration, error) {
+	raw := r.URL.Query().Get(queryMaxAge)
+	if raw == "" {
+		return 0, nil
+	}
+
+	return time.ParseDuration(raw)
+}
+
+// updateTrackedCitiesHandler replaces the set of cities the poller
+// subsystem refreshes from upstream. The poller picks up the change on its
+// next tick via the shared tracked cities file. It responds 501 if no
+// shared file is configured, since the update would otherwise silently
+// have no effect on the separate poller process.
+func updateTrackedCitiesHandler(trackedCities *poller.TrackedCities) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var cities []string
+		if err := json.NewDecoder(r.Body).Decode(&cities); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		if err := trackedCities.Set(cities); err != nil {
+			if errors.Is(err, poller.ErrNoSharedState) {
+				http.Error(w, fmt.Sprintf("Updating tracked cities requires %s to be set", envTrackedCitiesFile), http.StatusNotImplemented)
+				return
+			}
+
+			log.Printf("update tracked cities: %v\n", err)
+			http.Error(w, "Failed to update tracked cities", http.StatusInternalServerError)
+			return
+		}
+
+		render.JSON(w, r, cities)
+	}
+}
+
 // updateWeatherHandler processes requests for updating information about weather for the specific city
-func updateWeatherHandler(storage *models.WeatherStorage) http.HandlerFunc {
+func updateWeatherHandler(storage *models.WeatherStorage, responseCache *weathermw.ResponseCache) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		city := chi.URLParam(r, urlParamCity)
 		if city == "" {
@@ -124,6 +539,10 @@ func updateWeatherHandler(storage *models.WeatherStorage) http.HandlerFunc {
 		// updates info about the weather
 		storage.UpdateWeather(&weatherUpdate)
 
+		// invalidates every cached GET response variant for this city (e.g.
+		// across distinct ?max_age= values), if any
+		responseCache.InvalidatePrefix(weathermw.CacheKeyForCity(city))
+
 		// returns updated data
 		render.JSON(w, r, weatherUpdate)
 	}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/kurushqosimi/rocket_factory/pkg/models"
+	"github.com/kurushqosimi/rocket_factory/pkg/poller"
+	"github.com/kurushqosimi/rocket_factory/pkg/provider"
+)
+
+const (
+	metricsPort = "8081"
+
+	envTrackedCities     = "POLLER_TRACKED_CITIES"
+	envTrackedCitiesFile = "POLLER_TRACKED_CITIES_FILE"
+	envPollInterval      = "POLLER_INTERVAL"
+	envPollBatchSize     = "POLLER_BATCH_SIZE"
+	envCacheFile         = "WEATHER_CACHE_FILE"
+
+	readHeaderTimeout = 5 * time.Second
+	shutdownTimeout   = 10 * time.Second
+)
+
+func main() {
+	weatherProvider := provider.NewOpenWeatherMapProvider(provider.LoadConfigFromEnv())
+
+	storage := models.NewWeatherStorage(storageOptionsFromEnv()...)
+	defer func() {
+		if err := storage.Close(); err != nil {
+			log.Printf("Error closing weather storage: %v\n", err)
+		}
+	}()
+
+	tracked := poller.NewTrackedCities(initialTrackedCities(), os.Getenv(envTrackedCitiesFile))
+
+	p := poller.New(storage, weatherProvider, tracked, pollerConfigFromEnv())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go p.Run(ctx)
+
+	// metrics server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		if err := p.Metrics().WriteTo(w); err != nil {
+			http.Error(w, "Failed to render metrics", http.StatusInternalServerError)
+		}
+	})
+
+	server := &http.Server{
+		Addr:              ":" + metricsPort,
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	go func() {
+		log.Printf("Poller metrics server started on port %s\n", metricsPort)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("Failed to start metrics server: %v\n", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Println("Shutting poller down...")
+
+	cancel()
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer shutdownCancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Error trying metrics server shut down: %v\n", err)
+	}
+
+	log.Println("Poller stopped")
+}
+
+// initialTrackedCities reads the comma-separated POLLER_TRACKED_CITIES
+// env var used to seed the tracked city list on first run.
+func initialTrackedCities() []string {
+	raw := os.Getenv(envTrackedCities)
+	if raw == "" {
+		return nil
+	}
+
+	cities := strings.Split(raw, ",")
+	for i, city := range cities {
+		cities[i] = strings.TrimSpace(city)
+	}
+
+	return cities
+}
+
+// pollerConfigFromEnv builds a poller.Config from environment variables.
+func pollerConfigFromEnv() poller.Config {
+	cfg := poller.Config{
+		Interval:  poller.DefaultInterval,
+		BatchSize: poller.DefaultBatchSize,
+	}
+
+	if raw := os.Getenv(envPollInterval); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil {
+			cfg.Interval = d
+		} else {
+			log.Printf("Invalid %s value %q: %v\n", envPollInterval, raw, err)
+		}
+	}
+
+	if raw := os.Getenv(envPollBatchSize); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil {
+			cfg.BatchSize = n
+		} else {
+			log.Printf("Invalid %s value %q: %v\n", envPollBatchSize, raw, err)
+		}
+	}
+
+	return cfg
+}
+
+// storageOptionsFromEnv applies the same WEATHER_CACHE_FILE setting as
+// cmd/http_server, so both processes persist to the same cache file. Each
+// process reads that file only at startup and overwrites it on every flush;
+// neither picks up the other's updates while it runs.
+func storageOptionsFromEnv() []models.Option {
+	var opts []models.Option
+
+	if path := os.Getenv(envCacheFile); path != "" {
+		opts = append(opts, models.WithCacheLocation(path))
+	}
+
+	return opts
+}
@@ -0,0 +1,10 @@
+package auth
+
+import "github.com/golang-jwt/jwt/v5"
+
+// Claims are the JWT claims issued and verified by this package.
+type Claims struct {
+	jwt.RegisteredClaims
+	// Role is used for the role-based authorization checks in middleware.go.
+	Role string `json:"role"`
+}
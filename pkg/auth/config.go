@@ -0,0 +1,63 @@
+// Package auth provides JWT-based authentication and role based
+// authorization middleware for the HTTP server, plus a small token issuer
+// used both by the /api/v1/auth/token endpoint and in tests.
+package auth
+
+import (
+	"os"
+	"time"
+)
+
+const (
+	envHMACSecret         = "AUTH_HMAC_SECRET"
+	envJWKSURL            = "AUTH_JWKS_URL"
+	envRequireAuthOnReads = "AUTH_REQUIRE_READS"
+	envTokenTTL           = "AUTH_TOKEN_TTL"
+	envHtpasswdFile       = "AUTH_HTPASSWD_FILE"
+
+	defaultTokenTTL = 15 * time.Minute
+
+	// RoleWriter is the role required to perform mutating requests.
+	RoleWriter = "writer"
+	// RoleReader is the role granted to accounts that may only read.
+	RoleReader = "reader"
+)
+
+// Config holds the settings needed to verify and issue tokens.
+type Config struct {
+	// Enabled toggles auth enforcement on mutating endpoints.
+	Enabled bool
+	// HMACSecret, when set, is used to verify (and issue) HS256 tokens.
+	HMACSecret []byte
+	// JWKSURL, when set, is used to verify RS256 tokens against a remote key set.
+	JWKSURL string
+	// RequireAuthOnReads additionally requires a valid token on GET requests.
+	RequireAuthOnReads bool
+	// TokenTTL is how long tokens issued by /api/v1/auth/token remain valid.
+	TokenTTL time.Duration
+	// HtpasswdFile is the path to the htpasswd-style credentials file backing
+	// /api/v1/auth/token.
+	HtpasswdFile string
+}
+
+// LoadConfigFromEnv builds a Config from environment variables.
+func LoadConfigFromEnv() Config {
+	secret := os.Getenv(envHMACSecret)
+
+	cfg := Config{
+		Enabled:            secret != "" || os.Getenv(envJWKSURL) != "",
+		HMACSecret:         []byte(secret),
+		JWKSURL:            os.Getenv(envJWKSURL),
+		RequireAuthOnReads: os.Getenv(envRequireAuthOnReads) == "true",
+		TokenTTL:           defaultTokenTTL,
+		HtpasswdFile:       os.Getenv(envHtpasswdFile),
+	}
+
+	if raw := os.Getenv(envTokenTTL); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil {
+			cfg.TokenTTL = d
+		}
+	}
+
+	return cfg
+}
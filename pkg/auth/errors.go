@@ -0,0 +1,8 @@
+package auth
+
+import "errors"
+
+var (
+	errMissingToken = errors.New("missing bearer token")
+	errInvalidToken = errors.New("invalid or expired token")
+)
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Credential is a single htpasswd-style entry: a bcrypt password hash plus
+// the role to issue tokens with.
+type Credential struct {
+	PasswordHash string
+	Role         string
+}
+
+// LoadHtpasswd reads a username:bcryptHash:role file, one entry per line.
+// Role defaults to RoleReader when omitted, matching plain htpasswd files.
+func LoadHtpasswd(path string) (map[string]Credential, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open htpasswd file: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	creds := make(map[string]Credential)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) < 2 {
+			continue
+		}
+
+		cred := Credential{PasswordHash: parts[1], Role: RoleReader}
+		if len(parts) == 3 && parts[2] != "" {
+			cred.Role = parts[2]
+		}
+
+		creds[parts[0]] = cred
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read htpasswd file: %w", err)
+	}
+
+	return creds, nil
+}
+
+// CheckPassword reports whether password matches the bcrypt hash.
+func CheckPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestLoadHtpasswdParsesRoles(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword() returned error: %v", err)
+	}
+
+	contents := "alice:" + string(hash) + ":" + RoleWriter + "\n" +
+		"bob:" + string(hash) + "\n" +
+		"# a comment\n\n"
+
+	path := filepath.Join(t.TempDir(), "htpasswd")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+
+	creds, err := LoadHtpasswd(path)
+	if err != nil {
+		t.Fatalf("LoadHtpasswd() returned error: %v", err)
+	}
+
+	alice, ok := creds["alice"]
+	if !ok {
+		t.Fatal("creds[\"alice\"] missing")
+	}
+	if alice.Role != RoleWriter {
+		t.Errorf("alice.Role = %q, want %q", alice.Role, RoleWriter)
+	}
+	if !CheckPassword(alice.PasswordHash, "s3cret") {
+		t.Error("CheckPassword() failed for alice with the correct password")
+	}
+
+	bob, ok := creds["bob"]
+	if !ok {
+		t.Fatal("creds[\"bob\"] missing")
+	}
+	if bob.Role != RoleReader {
+		t.Errorf("bob.Role = %q, want %q (default)", bob.Role, RoleReader)
+	}
+}
+
+func TestCheckPasswordRejectsWrongPassword(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword() returned error: %v", err)
+	}
+
+	if CheckPassword(string(hash), "wrong") {
+		t.Error("CheckPassword() succeeded with the wrong password")
+	}
+}
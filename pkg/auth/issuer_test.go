@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIssuerHMACVerifierRoundTrip(t *testing.T) {
+	issuer := NewIssuer([]byte("test-secret"), time.Minute)
+	verifier := NewHMACVerifier([]byte("test-secret"))
+
+	token, err := issuer.Issue("alice", RoleWriter)
+	if err != nil {
+		t.Fatalf("Issue() returned error: %v", err)
+	}
+
+	claims, err := verifier.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify() returned error: %v", err)
+	}
+
+	if claims.Subject != "alice" {
+		t.Errorf("Subject = %q, want %q", claims.Subject, "alice")
+	}
+
+	if claims.Role != RoleWriter {
+		t.Errorf("Role = %q, want %q", claims.Role, RoleWriter)
+	}
+}
+
+func TestHMACVerifierRejectsWrongSecret(t *testing.T) {
+	issuer := NewIssuer([]byte("test-secret"), time.Minute)
+	verifier := NewHMACVerifier([]byte("wrong-secret"))
+
+	token, err := issuer.Issue("alice", RoleReader)
+	if err != nil {
+		t.Fatalf("Issue() returned error: %v", err)
+	}
+
+	if _, err := verifier.Verify(token); err == nil {
+		t.Error("Verify() succeeded with the wrong secret, want error")
+	}
+}
+
+func TestHMACVerifierRejectsExpiredToken(t *testing.T) {
+	issuer := NewIssuer([]byte("test-secret"), -time.Minute)
+	verifier := NewHMACVerifier([]byte("test-secret"))
+
+	token, err := issuer.Issue("alice", RoleReader)
+	if err != nil {
+		t.Fatalf("Issue() returned error: %v", err)
+	}
+
+	if _, err := verifier.Verify(token); err == nil {
+		t.Error("Verify() succeeded with an expired token, want error")
+	}
+}
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func handlerOK() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func TestRequireAuthRejectsMissingToken(t *testing.T) {
+	verifier := NewHMACVerifier([]byte("test-secret"))
+	handler := RequireAuth(verifier)(handlerOK())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/weather/london", nil))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestRequireAuthAcceptsValidToken(t *testing.T) {
+	issuer := NewIssuer([]byte("test-secret"), time.Minute)
+	verifier := NewHMACVerifier([]byte("test-secret"))
+	handler := RequireAuth(verifier)(handlerOK())
+
+	token, err := issuer.Issue("alice", RoleReader)
+	if err != nil {
+		t.Fatalf("Issue() returned error: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/weather/london", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestRequireRoleRejectsWrongRole(t *testing.T) {
+	issuer := NewIssuer([]byte("test-secret"), time.Minute)
+	verifier := NewHMACVerifier([]byte("test-secret"))
+	handler := RequireRole(verifier, RoleWriter)(handlerOK())
+
+	token, err := issuer.Issue("alice", RoleReader)
+	if err != nil {
+		t.Fatalf("Issue() returned error: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/weather/london", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestRequireRoleAcceptsMatchingRole(t *testing.T) {
+	issuer := NewIssuer([]byte("test-secret"), time.Minute)
+	verifier := NewHMACVerifier([]byte("test-secret"))
+	handler := RequireRole(verifier, RoleWriter)(handlerOK())
+
+	token, err := issuer.Issue("alice", RoleWriter)
+	if err != nil {
+		t.Fatalf("Issue() returned error: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/weather/london", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
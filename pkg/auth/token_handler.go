@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+// tokenResponse is the JSON body returned by TokenHandler.
+type tokenResponse struct {
+	Token string `json:"token"`
+	Role  string `json:"role"`
+}
+
+// TokenHandler issues a short-lived JWT for the basic-auth credentials
+// supplied on the request, checked against creds.
+func TokenHandler(issuer *Issuer, creds map[string]Credential) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Basic realm="weather"`)
+			http.Error(w, "Basic auth credentials are required", http.StatusUnauthorized)
+			return
+		}
+
+		cred, ok := creds[username]
+		if !ok || !CheckPassword(cred.PasswordHash, password) {
+			http.Error(w, "Invalid credentials", http.StatusUnauthorized)
+			return
+		}
+
+		token, err := issuer.Issue(username, cred.Role)
+		if err != nil {
+			http.Error(w, "Failed to issue token", http.StatusInternalServerError)
+			return
+		}
+
+		render.JSON(w, r, tokenResponse{Token: token, Role: cred.Role})
+	}
+}
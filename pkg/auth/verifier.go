@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Verifier validates a raw JWT string and returns its claims.
+type Verifier interface {
+	Verify(tokenString string) (*Claims, error)
+}
+
+// HMACVerifier verifies HS256 tokens signed with a shared secret.
+type HMACVerifier struct {
+	secret []byte
+}
+
+// NewHMACVerifier creates a Verifier for HS256 tokens signed with secret.
+func NewHMACVerifier(secret []byte) *HMACVerifier {
+	return &HMACVerifier{secret: secret}
+}
+
+// Verify parses and validates tokenString, returning its claims.
+func (v *HMACVerifier) Verify(tokenString string) (*Claims, error) {
+	var claims Claims
+
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		return v.secret, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("parse token: %w", err)
+	}
+
+	return &claims, nil
+}
@@ -0,0 +1,211 @@
+package middleware
+
+import (
+	"bytes"
+	"container/list"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResponseCacheConfig configures the in-memory LRU response cache.
+type ResponseCacheConfig struct {
+	// Enabled toggles the middleware on or off.
+	Enabled bool
+	// Capacity is the maximum number of cached responses.
+	Capacity int
+	// TTL is how long a cached response stays fresh.
+	TTL time.Duration
+	// RefreshKey, when non-empty, lets a request force a cache refresh by
+	// passing ?refresh_key=<RefreshKey>.
+	RefreshKey string
+}
+
+// cachedResponse is a captured HTTP response.
+type cachedResponse struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+	storedAt   time.Time
+}
+
+// ResponseCache is a capacity-bounded, TTL-aware LRU cache of HTTP responses
+// keyed by an arbitrary string (typically the request path and query string).
+type ResponseCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+// entry is the value stored in the LRU list.
+type entry struct {
+	key      string
+	response *cachedResponse
+}
+
+// CacheKeyForCity builds the cache key prefix used for the weather GET
+// endpoint of a given city, so callers invalidating entries (e.g. after a
+// PUT) agree with the key CacheGet derives from the request. CacheGet
+// appends the query string, so this matches every cached variant (e.g.
+// different ?max_age= values) for the city.
+func CacheKeyForCity(city string) string {
+	return "/api/v1/weather/" + city
+}
+
+// NewResponseCache creates a ResponseCache from cfg.
+func NewResponseCache(cfg ResponseCacheConfig) *ResponseCache {
+	return &ResponseCache{
+		capacity: cfg.Capacity,
+		ttl:      cfg.TTL,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached response for key if present and not expired.
+func (c *ResponseCache) Get(key string) (*cachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	resp := el.Value.(*entry).response
+	if time.Since(resp.storedAt) > c.ttl {
+		c.removeElement(el)
+		return nil, false
+	}
+
+	c.ll.MoveToFront(el)
+
+	return resp, true
+}
+
+// Set stores resp under key, evicting the least recently used entry if the
+// cache is at capacity.
+func (c *ResponseCache) Set(key string, resp *cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*entry).response = resp
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	el := c.ll.PushFront(&entry{key: key, response: resp})
+	c.items[key] = el
+
+	if c.capacity > 0 && c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.removeElement(oldest)
+		}
+	}
+}
+
+// Invalidate removes key from the cache, if present.
+func (c *ResponseCache) Invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.removeElement(el)
+	}
+}
+
+// InvalidatePrefix removes every entry cached for path, regardless of
+// query string. It is used to invalidate all cached query-string variants
+// (e.g. different ?max_age= values) of a single path.
+func (c *ResponseCache) InvalidatePrefix(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, el := range c.items {
+		if key == path || strings.HasPrefix(key, path+"?") {
+			c.removeElement(el)
+		}
+	}
+}
+
+// removeElement removes el from both the list and the index. Callers must
+// hold c.mu.
+func (c *ResponseCache) removeElement(el *list.Element) {
+	c.ll.Remove(el)
+	delete(c.items, el.Value.(*entry).key)
+}
+
+// responseRecorder captures a handler's response so it can be cached and
+// still written through to the real ResponseWriter.
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(statusCode int) {
+	rec.statusCode = statusCode
+	rec.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
+
+// CacheGet returns chi-compatible middleware that caches successful GET
+// responses in cache, keyed by request path and query string so that
+// distinct query parameters (e.g. ?max_age=) are cached separately. A
+// request carrying ?refresh_key=<cfg.RefreshKey> bypasses the cached copy
+// and forces a fresh response, which then replaces the cached entry.
+func CacheGet(cache *ResponseCache, refreshKey string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			query := r.URL.Query()
+			query.Del("refresh_key")
+
+			key := r.URL.Path
+			if encoded := query.Encode(); encoded != "" {
+				key += "?" + encoded
+			}
+
+			forceRefresh := refreshKey != "" && r.URL.Query().Get("refresh_key") == refreshKey
+			if !forceRefresh {
+				if cached, ok := cache.Get(key); ok {
+					for k, values := range cached.header {
+						for _, v := range values {
+							w.Header().Add(k, v)
+						}
+					}
+					w.Header().Set("X-Cache", "HIT")
+					w.WriteHeader(cached.statusCode)
+					_, _ = w.Write(cached.body)
+					return
+				}
+			}
+
+			rec := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			if rec.statusCode == http.StatusOK {
+				cache.Set(key, &cachedResponse{
+					statusCode: rec.statusCode,
+					header:     w.Header().Clone(),
+					body:       rec.body.Bytes(),
+					storedAt:   time.Now(),
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestResponseCacheGetSetRoundTrip(t *testing.T) {
+	cache := NewResponseCache(ResponseCacheConfig{Capacity: 10, TTL: time.Hour})
+
+	resp := &cachedResponse{statusCode: http.StatusOK, storedAt: time.Now()}
+	cache.Set("/a", resp)
+
+	got, ok := cache.Get("/a")
+	if !ok {
+		t.Fatal("Get() ok = false, want true")
+	}
+	if got != resp {
+		t.Error("Get() returned a different response than what was stored")
+	}
+}
+
+func TestResponseCacheExpiresEntriesPastTTL(t *testing.T) {
+	cache := NewResponseCache(ResponseCacheConfig{Capacity: 10, TTL: time.Millisecond})
+
+	cache.Set("/a", &cachedResponse{statusCode: http.StatusOK, storedAt: time.Now()})
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := cache.Get("/a"); ok {
+		t.Error("Get() ok = true, want false for an expired entry")
+	}
+}
+
+func TestResponseCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewResponseCache(ResponseCacheConfig{Capacity: 2, TTL: time.Hour})
+
+	cache.Set("/a", &cachedResponse{statusCode: http.StatusOK, storedAt: time.Now()})
+	cache.Set("/b", &cachedResponse{statusCode: http.StatusOK, storedAt: time.Now()})
+	cache.Get("/a") // touch /a so /b becomes the least recently used entry
+	cache.Set("/c", &cachedResponse{statusCode: http.StatusOK, storedAt: time.Now()})
+
+	if _, ok := cache.Get("/b"); ok {
+		t.Error("/b should have been evicted as the least recently used entry")
+	}
+	if _, ok := cache.Get("/a"); !ok {
+		t.Error("/a should still be cached, it was accessed before /c was added")
+	}
+	if _, ok := cache.Get("/c"); !ok {
+		t.Error("/c should still be cached")
+	}
+}
+
+func TestResponseCacheInvalidatePrefixRemovesAllQueryVariants(t *testing.T) {
+	cache := NewResponseCache(ResponseCacheConfig{Capacity: 10, TTL: time.Hour})
+
+	cache.Set("/api/v1/weather/london", &cachedResponse{statusCode: http.StatusOK, storedAt: time.Now()})
+	cache.Set("/api/v1/weather/london?max_age=5m", &cachedResponse{statusCode: http.StatusOK, storedAt: time.Now()})
+	cache.Set("/api/v1/weather/londonderry", &cachedResponse{statusCode: http.StatusOK, storedAt: time.Now()})
+
+	cache.InvalidatePrefix("/api/v1/weather/london")
+
+	if _, ok := cache.Get("/api/v1/weather/london"); ok {
+		t.Error("/api/v1/weather/london should have been invalidated")
+	}
+	if _, ok := cache.Get("/api/v1/weather/london?max_age=5m"); ok {
+		t.Error("/api/v1/weather/london?max_age=5m should have been invalidated")
+	}
+	if _, ok := cache.Get("/api/v1/weather/londonderry"); !ok {
+		t.Error("/api/v1/weather/londonderry should not have been invalidated by a same-prefix city name")
+	}
+}
+
+func TestCacheGetKeysByQueryString(t *testing.T) {
+	cache := NewResponseCache(ResponseCacheConfig{Capacity: 10, TTL: time.Hour})
+
+	calls := 0
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(r.URL.RawQuery))
+	})
+
+	handler := CacheGet(cache, "")(next)
+
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/city?max_age=1m", nil))
+		if rec.Body.String() != "max_age=1m" {
+			t.Errorf("body = %q, want %q", rec.Body.String(), "max_age=1m")
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1 (second request should hit cache)", calls)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/city?max_age=5m", nil))
+	if calls != 2 {
+		t.Errorf("handler called %d times, want 2 (different query string should miss cache)", calls)
+	}
+	if rec.Body.String() != "max_age=5m" {
+		t.Errorf("body = %q, want %q", rec.Body.String(), "max_age=5m")
+	}
+}
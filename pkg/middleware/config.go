@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	envRateLimitEnabled = "RATE_LIMIT_ENABLED"
+	envRateLimitMaxRate = "RATE_LIMIT_MAX_RATE"
+	envRateLimitBurst   = "RATE_LIMIT_MAX_BURST"
+
+	envCacheEnabled    = "RESPONSE_CACHE_ENABLED"
+	envCacheCapacity   = "RESPONSE_CACHE_CAPACITY"
+	envCacheTTL        = "RESPONSE_CACHE_TTL"
+	envCacheRefreshKey = "RESPONSE_CACHE_REFRESH_KEY"
+
+	defaultMaxRate       = 5.0
+	defaultMaxBurst      = 10
+	defaultCacheCapacity = 1000
+	defaultCacheTTL      = time.Minute
+)
+
+// LoadRateLimiterConfigFromEnv builds a RateLimiterConfig from environment variables.
+func LoadRateLimiterConfigFromEnv() RateLimiterConfig {
+	cfg := RateLimiterConfig{
+		Enabled:  os.Getenv(envRateLimitEnabled) == "true",
+		MaxRate:  defaultMaxRate,
+		MaxBurst: defaultMaxBurst,
+	}
+
+	if v := os.Getenv(envRateLimitMaxRate); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.MaxRate = f
+		}
+	}
+
+	if v := os.Getenv(envRateLimitBurst); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.MaxBurst = n
+		}
+	}
+
+	return cfg
+}
+
+// LoadResponseCacheConfigFromEnv builds a ResponseCacheConfig from environment variables.
+func LoadResponseCacheConfigFromEnv() ResponseCacheConfig {
+	cfg := ResponseCacheConfig{
+		Enabled:    os.Getenv(envCacheEnabled) == "true",
+		Capacity:   defaultCacheCapacity,
+		TTL:        defaultCacheTTL,
+		RefreshKey: os.Getenv(envCacheRefreshKey),
+	}
+
+	if v := os.Getenv(envCacheCapacity); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Capacity = n
+		}
+	}
+
+	if v := os.Getenv(envCacheTTL); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.TTL = d
+		}
+	}
+
+	return cfg
+}
@@ -0,0 +1,168 @@
+// Package middleware provides chi-compatible HTTP middleware for rate
+// limiting and response caching.
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const rateLimiterSweepInterval = time.Minute
+
+// RateLimiterConfig configures a GCRA (Generic Cell Rate Algorithm) based
+// rate limiter.
+type RateLimiterConfig struct {
+	// Enabled toggles the middleware on or off.
+	Enabled bool
+	// MaxRate is the sustained number of allowed requests per second.
+	MaxRate float64
+	// MaxBurst is the number of requests allowed to exceed MaxRate momentarily.
+	MaxBurst int
+}
+
+// gcraState is the per-key state tracked by RateLimiter: the theoretical
+// arrival time (TAT) of the next conforming request.
+type gcraState struct {
+	tat time.Time
+}
+
+// RateLimiter is a GCRA-based rate limiter keyed by an arbitrary string
+// (typically client IP + route).
+type RateLimiter struct {
+	mu     sync.Mutex
+	states map[string]*gcraState
+
+	emissionInterval time.Duration
+	burstTolerance   time.Duration
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewRateLimiter creates a RateLimiter from cfg. It starts a background
+// goroutine that periodically forgets keys that have gone idle.
+func NewRateLimiter(cfg RateLimiterConfig) *RateLimiter {
+	emissionInterval := time.Duration(float64(time.Second) / cfg.MaxRate)
+
+	l := &RateLimiter{
+		states:           make(map[string]*gcraState),
+		emissionInterval: emissionInterval,
+		burstTolerance:   emissionInterval * time.Duration(cfg.MaxBurst),
+		stop:             make(chan struct{}),
+	}
+
+	go l.sweepLoop()
+
+	return l
+}
+
+// Allow reports whether a request for key conforms to the configured rate.
+// When it does not, retryAfter is the duration the caller should wait
+// before retrying.
+func (l *RateLimiter) Allow(key string) (allowed bool, retryAfter time.Duration) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	tat := now
+	if state, ok := l.states[key]; ok && state.tat.After(now) {
+		tat = state.tat
+	}
+
+	newTAT := tat.Add(l.emissionInterval)
+	allowAt := newTAT.Add(-l.burstTolerance)
+
+	if allowAt.After(now) {
+		return false, allowAt.Sub(now)
+	}
+
+	l.states[key] = &gcraState{tat: newTAT}
+
+	return true, 0
+}
+
+// Close stops the background sweep goroutine.
+func (l *RateLimiter) Close() {
+	l.stopOnce.Do(func() {
+		close(l.stop)
+	})
+}
+
+// sweepLoop periodically forgets keys whose TAT has long since passed, so
+// the state map does not grow unbounded with one-off clients.
+func (l *RateLimiter) sweepLoop() {
+	ticker := time.NewTicker(rateLimiterSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			now := time.Now()
+
+			l.mu.Lock()
+			for key, state := range l.states {
+				if now.Sub(state.tat) > rateLimiterSweepInterval {
+					delete(l.states, key)
+				}
+			}
+			l.mu.Unlock()
+		case <-l.stop:
+			return
+		}
+	}
+}
+
+// RateLimit returns chi-compatible middleware that rejects requests
+// exceeding the configured rate with 429 Too Many Requests.
+func RateLimit(limiter *RateLimiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := clientKey(r)
+
+			allowed, retryAfter := limiter.Allow(key)
+			if !allowed {
+				w.Header().Set("Retry-After", formatRetryAfter(retryAfter))
+				http.Error(w, "Too many requests", http.StatusTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientKey derives the rate limiting key from the client IP and route.
+func clientKey(r *http.Request) string {
+	return clientIP(r) + ":" + r.Method + ":" + r.URL.Path
+}
+
+// clientIP extracts the client IP from the request. It intentionally
+// ignores X-Forwarded-For: without a trusted-proxy allowlist or hop-count
+// configuration, that header is client-controlled and would let a client
+// dodge the limiter, or frame another client, by setting it at will.
+//
+// r.RemoteAddr is "host:port"; the port is stripped since it changes on
+// every new TCP connection and would otherwise give a non-keep-alive
+// client a fresh rate-limit bucket on every request.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// formatRetryAfter formats a duration as whole seconds for the Retry-After header.
+func formatRetryAfter(d time.Duration) string {
+	seconds := int(d.Round(time.Second).Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	return strconv.Itoa(seconds)
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientIPStripsPort(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "203.0.113.5:54321"
+
+	if got := clientIP(req); got != "203.0.113.5" {
+		t.Errorf("clientIP() = %q, want %q", got, "203.0.113.5")
+	}
+}
+
+func TestClientIPFallsBackToRawRemoteAddr(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "not-a-host-port"
+
+	if got := clientIP(req); got != "not-a-host-port" {
+		t.Errorf("clientIP() = %q, want %q", got, "not-a-host-port")
+	}
+}
+
+func TestRateLimiterAllowsWithinBurst(t *testing.T) {
+	limiter := NewRateLimiter(RateLimiterConfig{MaxRate: 1, MaxBurst: 3})
+	defer limiter.Close()
+
+	for i := 0; i < 3; i++ {
+		if allowed, _ := limiter.Allow("client"); !allowed {
+			t.Fatalf("request %d: allowed = false, want true within burst", i)
+		}
+	}
+
+	allowed, retryAfter := limiter.Allow("client")
+	if allowed {
+		t.Fatal("allowed = true, want false once the burst is exhausted")
+	}
+	if retryAfter <= 0 {
+		t.Errorf("retryAfter = %v, want > 0", retryAfter)
+	}
+}
+
+func TestRateLimiterTracksKeysIndependently(t *testing.T) {
+	limiter := NewRateLimiter(RateLimiterConfig{MaxRate: 1, MaxBurst: 1})
+	defer limiter.Close()
+
+	if allowed, _ := limiter.Allow("a"); !allowed {
+		t.Fatal("first request for key a should be allowed")
+	}
+	if allowed, _ := limiter.Allow("a"); allowed {
+		t.Fatal("second immediate request for key a should be denied")
+	}
+	if allowed, _ := limiter.Allow("b"); !allowed {
+		t.Fatal("first request for key b should be allowed, independent of key a")
+	}
+}
+
+func TestFormatRetryAfterRoundsToNearestSecondWithMinimumOfOne(t *testing.T) {
+	if got := formatRetryAfter(100 * time.Millisecond); got != "1" {
+		t.Errorf("formatRetryAfter(100ms) = %q, want %q", got, "1")
+	}
+	if got := formatRetryAfter(1200 * time.Millisecond); got != "1" {
+		t.Errorf("formatRetryAfter(1.2s) = %q, want %q", got, "1")
+	}
+	if got := formatRetryAfter(1800 * time.Millisecond); got != "2" {
+		t.Errorf("formatRetryAfter(1.8s) = %q, want %q", got, "2")
+	}
+}
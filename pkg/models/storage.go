@@ -1,39 +1,248 @@
 package models
 
-import "sync"
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
 
-// WeatherStorage is a thread safe storage for weather
+const (
+	// defaultTTL is the freshness window used when no TTL is configured.
+	defaultTTL = 10 * time.Minute
+	// purgeInterval is how often the background goroutine sweeps expired entries.
+	purgeInterval = time.Minute
+)
+
+// cacheEntry wraps a Weather value together with the time it was stored,
+// which is what TTL freshness checks are computed against.
+type cacheEntry struct {
+	Weather  *Weather  `json:"weather"`
+	StoredAt time.Time `json:"stored_at"`
+}
+
+// WeatherStorage is a thread safe, TTL-aware, disk-backed cache of weather
+// data keyed by city name.
 type WeatherStorage struct {
 	mu       sync.RWMutex
-	weathers map[string]*Weather
+	weathers map[string]*cacheEntry
+
+	ttl           time.Duration
+	cacheLocation string
+
+	subscribers *subscribers
+	timeSeries  TimeSeriesStore
+
+	stopPurge chan struct{}
+	purgeOnce sync.Once
+}
+
+// Option configures a WeatherStorage at construction time.
+type Option func(*WeatherStorage)
+
+// WithTTL sets the freshness window used to decide whether a cached entry is stale.
+func WithTTL(ttl time.Duration) Option {
+	return func(s *WeatherStorage) {
+		s.ttl = ttl
+	}
 }
 
-// NewWeatherStorage creates a new storage for weather data
-func NewWeatherStorage() *WeatherStorage {
-	return &WeatherStorage{
-		weathers: make(map[string]*Weather),
+// WithCacheLocation enables disk persistence at the given JSON file path.
+// The storage is loaded from this path at construction time.
+func WithCacheLocation(path string) Option {
+	return func(s *WeatherStorage) {
+		s.cacheLocation = path
+	}
+}
+
+// WithTimeSeriesStore makes UpdateWeather additionally append every update
+// to store, so history can be queried independently of the latest-value cache.
+func WithTimeSeriesStore(store TimeSeriesStore) Option {
+	return func(s *WeatherStorage) {
+		s.timeSeries = store
+	}
+}
+
+// NewWeatherStorage creates a new storage for weather data. It starts a
+// background goroutine that periodically evicts expired entries and, if a
+// cache location is configured, persists the remaining entries to disk.
+func NewWeatherStorage(opts ...Option) *WeatherStorage {
+	s := &WeatherStorage{
+		weathers:    make(map[string]*cacheEntry),
+		ttl:         defaultTTL,
+		subscribers: newSubscribers(),
+		stopPurge:   make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.cacheLocation != "" {
+		if err := s.Load(); err != nil && !os.IsNotExist(err) {
+			fmt.Printf("weather storage: failed to load cache from disk: %v\n", err)
+		}
 	}
+
+	go s.purgeLoop()
+
+	return s
 }
 
-// GetWeather returns a data about a weather by the city name
-// if not found returns nil
-func (s *WeatherStorage) GetWeather(city string) *Weather {
+// GetWeather returns the cached data for the given city. maxAge overrides
+// the storage's configured TTL for this lookup when positive. stale
+// reports whether the entry is older than the effective freshness window;
+// it is always true when the city is not present.
+func (s *WeatherStorage) GetWeather(city string, maxAge time.Duration) (weather *Weather, stale bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	weather, ok := s.weathers[city]
+	entry, ok := s.weathers[city]
 	if !ok {
-		return nil
+		return nil, true
+	}
+
+	freshness := s.ttl
+	if maxAge > 0 {
+		freshness = maxAge
 	}
 
-	return weather
+	return entry.Weather, time.Since(entry.StoredAt) > freshness
 }
 
-// UpdateWeather updates weather information for the given city
-// if it does not exist creates one
+// UpdateWeather updates weather information for the given city, resetting
+// its freshness window. If it does not exist it is created.
 func (s *WeatherStorage) UpdateWeather(weather *Weather) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.weathers[weather.City] = weather
+	s.weathers[weather.City] = &cacheEntry{
+		Weather:  weather,
+		StoredAt: time.Now(),
+	}
+
+	if s.timeSeries != nil {
+		sample := Sample{Weather: weather, Timestamp: weather.UpdatedAt}
+		if err := s.timeSeries.Append(weather.City, sample); err != nil {
+			fmt.Printf("weather storage: failed to append time series sample: %v\n", err)
+		}
+	}
+
+	s.subscribers.publish(weather)
+}
+
+// TimeSeries returns the configured TimeSeriesStore, or nil if none was set
+// via WithTimeSeriesStore.
+func (s *WeatherStorage) TimeSeries() TimeSeriesStore {
+	return s.timeSeries
+}
+
+// SetTTL changes the default freshness window used by GetWeather.
+func (s *WeatherStorage) SetTTL(ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ttl = ttl
+}
+
+// Purge removes all entries older than the configured TTL.
+func (s *WeatherStorage) Purge() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for city, entry := range s.weathers {
+		if time.Since(entry.StoredAt) > s.ttl {
+			delete(s.weathers, city)
+		}
+	}
+}
+
+// Load reads the cache location from disk and replaces the in-memory
+// entries with its contents.
+func (s *WeatherStorage) Load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cacheLocation == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.cacheLocation)
+	if err != nil {
+		return fmt.Errorf("read cache file: %w", err)
+	}
+
+	var entries map[string]*cacheEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("decode cache file: %w", err)
+	}
+
+	s.weathers = entries
+
+	return nil
+}
+
+// Flush writes the current entries to the configured cache location.
+func (s *WeatherStorage) Flush() error {
+	s.mu.RLock()
+	data, err := json.Marshal(s.weathers)
+	s.mu.RUnlock()
+
+	if err != nil {
+		return fmt.Errorf("encode cache file: %w", err)
+	}
+
+	if s.cacheLocation == "" {
+		return nil
+	}
+
+	if dir := filepath.Dir(s.cacheLocation); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create cache dir: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(s.cacheLocation, data, 0o644); err != nil {
+		return fmt.Errorf("write cache file: %w", err)
+	}
+
+	return nil
+}
+
+// Close stops the background purge goroutine, flushing one last time if a
+// cache location is configured, and closes the time series store if one was configured.
+func (s *WeatherStorage) Close() error {
+	s.purgeOnce.Do(func() {
+		close(s.stopPurge)
+	})
+
+	if err := s.Flush(); err != nil {
+		return err
+	}
+
+	if s.timeSeries != nil {
+		return s.timeSeries.Close()
+	}
+
+	return nil
+}
+
+// purgeLoop periodically evicts expired entries and persists the cache to disk.
+func (s *WeatherStorage) purgeLoop() {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.Purge()
+			if err := s.Flush(); err != nil {
+				fmt.Printf("weather storage: failed to flush cache to disk: %v\n", err)
+			}
+		case <-s.stopPurge:
+			return
+		}
+	}
 }
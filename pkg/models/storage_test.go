@@ -0,0 +1,92 @@
+package models
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetWeatherMissingCityIsStale(t *testing.T) {
+	storage := NewWeatherStorage()
+	defer func() { _ = storage.Close() }()
+
+	weather, stale := storage.GetWeather("london", 0)
+	if weather != nil {
+		t.Errorf("weather = %+v, want nil", weather)
+	}
+	if !stale {
+		t.Error("stale = false, want true for a missing city")
+	}
+}
+
+func TestUpdateWeatherIsFreshWithinTTL(t *testing.T) {
+	storage := NewWeatherStorage(WithTTL(time.Hour))
+	defer func() { _ = storage.Close() }()
+
+	storage.UpdateWeather(&Weather{City: "london", Temperature: 10})
+
+	weather, stale := storage.GetWeather("london", 0)
+	if weather == nil {
+		t.Fatal("weather = nil, want the stored entry")
+	}
+	if stale {
+		t.Error("stale = true, want false within the TTL")
+	}
+}
+
+func TestGetWeatherMaxAgeOverridesTTL(t *testing.T) {
+	storage := NewWeatherStorage(WithTTL(time.Hour))
+	defer func() { _ = storage.Close() }()
+
+	storage.UpdateWeather(&Weather{City: "london", Temperature: 10})
+	time.Sleep(5 * time.Millisecond)
+
+	if _, stale := storage.GetWeather("london", time.Millisecond); !stale {
+		t.Error("stale = false, want true when maxAge is shorter than the entry's age")
+	}
+}
+
+func TestPurgeRemovesExpiredEntries(t *testing.T) {
+	storage := NewWeatherStorage(WithTTL(time.Millisecond))
+	defer func() { _ = storage.Close() }()
+
+	storage.UpdateWeather(&Weather{City: "london", Temperature: 10})
+	time.Sleep(5 * time.Millisecond)
+
+	storage.Purge()
+
+	weather, stale := storage.GetWeather("london", 0)
+	if weather != nil {
+		t.Errorf("weather = %+v, want nil after purge", weather)
+	}
+	if !stale {
+		t.Error("stale = false, want true after purge")
+	}
+}
+
+func TestLoadFlushRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+
+	storage := NewWeatherStorage(WithCacheLocation(path))
+	storage.UpdateWeather(&Weather{City: "paris", Temperature: 20})
+
+	if err := storage.Close(); err != nil {
+		t.Fatalf("Close() returned error: %v", err)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("cache file not written: %v", err)
+	}
+
+	reloaded := NewWeatherStorage(WithCacheLocation(path))
+	defer func() { _ = reloaded.Close() }()
+
+	weather, _ := reloaded.GetWeather("paris", time.Hour)
+	if weather == nil {
+		t.Fatal("weather = nil, want the entry loaded from disk")
+	}
+	if weather.Temperature != 20 {
+		t.Errorf("Temperature = %v, want 20", weather.Temperature)
+	}
+}
@@ -0,0 +1,81 @@
+package models
+
+import "sync"
+
+// subscribers is a fan-out registry of channels that get notified whenever
+// UpdateWeather is called. It is protected by its own mutex, so Subscribe
+// and unsubscribe never take WeatherStorage's main read/write lock;
+// UpdateWeather publishes while it holds that lock.
+type subscribers struct {
+	mu     sync.RWMutex
+	nextID int
+	subs   map[int]chan<- *Weather
+}
+
+func newSubscribers() *subscribers {
+	return &subscribers{
+		subs: make(map[int]chan<- *Weather),
+	}
+}
+
+// add registers ch and returns the id used to remove it again.
+func (s *subscribers) add(ch chan<- *Weather) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.nextID
+	s.nextID++
+	s.subs[id] = ch
+
+	return id
+}
+
+// remove unregisters the channel with the given id.
+func (s *subscribers) remove(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.subs, id)
+}
+
+// publish fans weather out to every registered subscriber. Slow
+// subscribers are skipped rather than blocking the publisher.
+func (s *subscribers) publish(weather *Weather) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, ch := range s.subs {
+		select {
+		case ch <- weather:
+		default:
+		}
+	}
+}
+
+// Subscribe registers ch to receive every Weather passed to UpdateWeather
+// from now on. The returned unsubscribe func removes the registration; it
+// is safe to call more than once.
+func (s *WeatherStorage) Subscribe(ch chan<- *Weather) (unsubscribe func()) {
+	id := s.subscribers.add(ch)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.subscribers.remove(id)
+		})
+	}
+}
+
+// All returns a snapshot of every currently cached Weather, in no
+// particular order.
+func (s *WeatherStorage) All() []*Weather {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	all := make([]*Weather, 0, len(s.weathers))
+	for _, entry := range s.weathers {
+		all = append(all, entry.Weather)
+	}
+
+	return all
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Sample is a single historical observation recorded for a city.
+type Sample struct {
+	Weather   *Weather  `json:"weather"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// TimeSeriesStore persists the history of weather observations for a city,
+// independently of WeatherStorage's latest-value cache. Implementations
+// live in pkg/timeseries so this package stays free of their dependencies
+// (e.g. sqlite or influxdb drivers behind build tags).
+type TimeSeriesStore interface {
+	// Append records sample for city.
+	Append(city string, sample Sample) error
+	// Query returns every sample recorded for city within [from, to], ordered by time.
+	Query(city string, from, to time.Time) ([]Sample, error)
+	// Close releases any resources held by the store.
+	Close() error
+}
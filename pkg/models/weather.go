@@ -6,8 +6,43 @@ import "time"
 type Weather struct {
 	// Name of the city
 	City string `json:"city"`
+	// Geographic coordinates of the city
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+
 	// Temperature in Celsius
 	Temperature float64 `json:"temperature"`
+	// FeelsLike is the perceived temperature in Celsius
+	FeelsLike float64 `json:"feels_like"`
+	// TempMin is the minimum observed temperature in Celsius
+	TempMin float64 `json:"temp_min"`
+	// TempMax is the maximum observed temperature in Celsius
+	TempMax float64 `json:"temp_max"`
+
+	// Pressure in hPa
+	Pressure int `json:"pressure"`
+	// Humidity in percent
+	Humidity int `json:"humidity"`
+
+	// WindSpeed in meters/sec
+	WindSpeed float64 `json:"wind_speed"`
+	// WindDeg is the wind direction in degrees
+	WindDeg int `json:"wind_deg"`
+
+	// Clouds is the cloudiness in percent
+	Clouds int `json:"clouds"`
+	// PrecipitationMM is the precipitation volume for the last hour in mm
+	PrecipitationMM float64 `json:"precipitation_mm"`
+
+	// Sunrise and Sunset times
+	Sunrise time.Time `json:"sunrise"`
+	Sunset  time.Time `json:"sunset"`
+
+	// Condition is a short human-readable description (e.g. "light rain")
+	Condition string `json:"condition"`
+	// ConditionCode is the upstream provider's numeric weather condition code
+	ConditionCode int `json:"condition_code"`
+
 	// Last updated time
 	UpdatedAt time.Time `json:"updated_at"`
 }
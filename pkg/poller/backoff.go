@@ -0,0 +1,72 @@
+package poller
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	backoffBase = 30 * time.Second
+	backoffMax  = 10 * time.Minute
+)
+
+// cityBackoff tracks consecutive failures for a single city.
+type cityBackoff struct {
+	failures     int
+	blockedUntil time.Time
+}
+
+// backoffTracker keeps per-city exponential backoff state so a city that
+// repeatedly fails to fetch does not get retried on every tick.
+type backoffTracker struct {
+	mu    sync.Mutex
+	state map[string]*cityBackoff
+}
+
+func newBackoffTracker() *backoffTracker {
+	return &backoffTracker{
+		state: make(map[string]*cityBackoff),
+	}
+}
+
+// ready reports whether city is due for a retry.
+func (b *backoffTracker) ready(city string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cb, ok := b.state[city]
+	if !ok {
+		return true
+	}
+
+	return !time.Now().Before(cb.blockedUntil)
+}
+
+// recordSuccess clears any backoff state for city.
+func (b *backoffTracker) recordSuccess(city string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.state, city)
+}
+
+// recordFailure increases the backoff for city, doubling up to backoffMax.
+func (b *backoffTracker) recordFailure(city string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cb, ok := b.state[city]
+	if !ok {
+		cb = &cityBackoff{}
+		b.state[city] = cb
+	}
+
+	cb.failures++
+
+	delay := backoffBase << uint(cb.failures-1)
+	if delay > backoffMax || delay <= 0 {
+		delay = backoffMax
+	}
+
+	cb.blockedUntil = time.Now().Add(delay)
+}
@@ -0,0 +1,86 @@
+package poller
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Metrics tracks per-city poll outcomes and is exposed over /metrics in
+// Prometheus text exposition format.
+type Metrics struct {
+	mu           sync.RWMutex
+	successTotal map[string]int64
+	failureTotal map[string]int64
+	lastUpdate   map[string]time.Time
+}
+
+// NewMetrics creates an empty Metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		successTotal: make(map[string]int64),
+		failureTotal: make(map[string]int64),
+		lastUpdate:   make(map[string]time.Time),
+	}
+}
+
+// RecordSuccess increments the success counter for city and updates its last-update timestamp.
+func (m *Metrics) RecordSuccess(city string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.successTotal[city]++
+	m.lastUpdate[city] = time.Now()
+}
+
+// RecordFailure increments the failure counter for city.
+func (m *Metrics) RecordFailure(city string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.failureTotal[city]++
+}
+
+// WriteTo renders the current metrics in Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cities := make(map[string]struct{}, len(m.successTotal)+len(m.failureTotal))
+	for city := range m.successTotal {
+		cities[city] = struct{}{}
+	}
+	for city := range m.failureTotal {
+		cities[city] = struct{}{}
+	}
+
+	sorted := make([]string, 0, len(cities))
+	for city := range cities {
+		sorted = append(sorted, city)
+	}
+	sort.Strings(sorted)
+
+	fmt.Fprintln(w, "# HELP poller_poll_success_total Number of successful upstream polls per city.")
+	fmt.Fprintln(w, "# TYPE poller_poll_success_total counter")
+	for _, city := range sorted {
+		fmt.Fprintf(w, "poller_poll_success_total{city=%q} %d\n", city, m.successTotal[city])
+	}
+
+	fmt.Fprintln(w, "# HELP poller_poll_failure_total Number of failed upstream polls per city.")
+	fmt.Fprintln(w, "# TYPE poller_poll_failure_total counter")
+	for _, city := range sorted {
+		fmt.Fprintf(w, "poller_poll_failure_total{city=%q} %d\n", city, m.failureTotal[city])
+	}
+
+	fmt.Fprintln(w, "# HELP poller_last_update_timestamp_seconds Unix timestamp of the last successful poll per city.")
+	fmt.Fprintln(w, "# TYPE poller_last_update_timestamp_seconds gauge")
+	for _, city := range sorted {
+		if ts, ok := m.lastUpdate[city]; ok {
+			fmt.Fprintf(w, "poller_last_update_timestamp_seconds{city=%q} %d\n", city, ts.Unix())
+		}
+	}
+
+	return nil
+}
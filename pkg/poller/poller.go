@@ -0,0 +1,149 @@
+// Package poller periodically refreshes a set of tracked cities from an
+// upstream WeatherProvider and writes the results into a WeatherStorage.
+package poller
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/kurushqosimi/rocket_factory/pkg/models"
+	"github.com/kurushqosimi/rocket_factory/pkg/provider"
+)
+
+const (
+	// DefaultInterval mirrors OpenWeatherMap's own update cadence.
+	DefaultInterval = 10 * time.Minute
+	// DefaultBatchSize is the number of cities fetched concurrently per
+	// tick. This limits concurrency only: WeatherProvider still issues one
+	// upstream request per city, since it takes city names rather than the
+	// numeric city IDs OWM's multi-city "group" endpoint requires.
+	DefaultBatchSize = 20
+	// maxJitter spreads ticks out to avoid a thundering herd against upstream.
+	maxJitter = 30 * time.Second
+)
+
+// Config configures a Poller.
+type Config struct {
+	// Interval is how often the tracked cities are refreshed.
+	Interval time.Duration
+	// BatchSize is how many cities are fetched concurrently per tick. It
+	// bounds concurrency only; see DefaultBatchSize.
+	BatchSize int
+}
+
+// Poller periodically refreshes Tracked's cities from Provider into Storage.
+type Poller struct {
+	storage  *models.WeatherStorage
+	provider provider.WeatherProvider
+	tracked  *TrackedCities
+	metrics  *Metrics
+	backoff  *backoffTracker
+
+	interval  time.Duration
+	batchSize int
+}
+
+// New creates a Poller.
+func New(storage *models.WeatherStorage, weatherProvider provider.WeatherProvider, tracked *TrackedCities, cfg Config) *Poller {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	return &Poller{
+		storage:   storage,
+		provider:  weatherProvider,
+		tracked:   tracked,
+		metrics:   NewMetrics(),
+		backoff:   newBackoffTracker(),
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Metrics returns the Poller's metrics, for wiring up a /metrics endpoint.
+func (p *Poller) Metrics() *Metrics {
+	return p.metrics
+}
+
+// Run polls on a jittered schedule until ctx is canceled.
+func (p *Poller) Run(ctx context.Context) {
+	for {
+		select {
+		case <-time.After(p.interval + jitter()):
+			p.pollAll(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// jitter returns a random duration in [0, maxJitter) to avoid every poller
+// replica waking up at exactly the same moment.
+func jitter() time.Duration {
+	return time.Duration(rand.Int63n(int64(maxJitter)))
+}
+
+// pollAll reloads the tracked city list and refreshes it in batches of up
+// to batchSize concurrent fetches. If the reload fails, the last-known
+// in-memory list is polled instead of skipping the tick entirely.
+func (p *Poller) pollAll(ctx context.Context) {
+	if err := p.tracked.Load(); err != nil {
+		fmt.Printf("poller: failed to reload tracked cities, using last-known list: %v\n", err)
+	}
+
+	cities := p.tracked.Get()
+
+	for start := 0; start < len(cities); start += p.batchSize {
+		end := start + p.batchSize
+		if end > len(cities) {
+			end = len(cities)
+		}
+
+		p.pollBatch(ctx, cities[start:end])
+	}
+}
+
+// pollBatch refreshes every city in batch concurrently. Each city still
+// costs its own upstream request; batch only bounds how many of those
+// requests run at once.
+func (p *Poller) pollBatch(ctx context.Context, batch []string) {
+	var wg sync.WaitGroup
+
+	for _, city := range batch {
+		if !p.backoff.ready(city) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(city string) {
+			defer wg.Done()
+			p.pollCity(ctx, city)
+		}(city)
+	}
+
+	wg.Wait()
+}
+
+// pollCity fetches and stores weather for a single city, updating metrics
+// and backoff state.
+func (p *Poller) pollCity(ctx context.Context, city string) {
+	weather, err := p.provider.FetchWeather(ctx, city)
+	if err != nil {
+		p.backoff.recordFailure(city)
+		p.metrics.RecordFailure(city)
+		return
+	}
+
+	p.storage.UpdateWeather(weather)
+	p.backoff.recordSuccess(city)
+	p.metrics.RecordSuccess(city)
+}
@@ -0,0 +1,113 @@
+package poller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// ErrNoSharedState is returned by Set when no shared file was configured
+// via NewTrackedCities, meaning the update would only be visible to the
+// calling process and would never reach a separate poller process.
+var ErrNoSharedState = errors.New("tracked cities: no shared file configured, update would not reach the poller process")
+
+// TrackedCities is the thread-safe, optionally disk-backed list of cities
+// the poller keeps refreshed from upstream. Persisting it to a shared file
+// lets an external process (the main HTTP server's PUT /api/v1/tracked
+// endpoint) update the list the poller reads on its next tick.
+type TrackedCities struct {
+	mu     sync.RWMutex
+	cities []string
+	path   string
+}
+
+// NewTrackedCities creates a TrackedCities seeded with initial. If path is
+// non-empty and already exists, its contents take precedence over initial.
+func NewTrackedCities(initial []string, path string) *TrackedCities {
+	t := &TrackedCities{
+		cities: initial,
+		path:   path,
+	}
+
+	if path != "" {
+		if err := t.Load(); err != nil && !os.IsNotExist(err) {
+			fmt.Printf("tracked cities: failed to load %s: %v\n", path, err)
+		}
+	}
+
+	return t
+}
+
+// Get returns the current tracked city list.
+func (t *TrackedCities) Get() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	cities := make([]string, len(t.cities))
+	copy(cities, t.cities)
+
+	return cities
+}
+
+// Set replaces the tracked city list and persists it to the configured
+// shared file. It returns ErrNoSharedState without making any change when
+// no path was configured, since in that case the update would only be
+// visible to the calling process.
+func (t *TrackedCities) Set(cities []string) error {
+	t.mu.RLock()
+	path := t.path
+	t.mu.RUnlock()
+
+	if path == "" {
+		return ErrNoSharedState
+	}
+
+	t.mu.Lock()
+	t.cities = cities
+	t.mu.Unlock()
+
+	data, err := json.Marshal(cities)
+	if err != nil {
+		return fmt.Errorf("encode tracked cities: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write tracked cities file: %w", err)
+	}
+
+	return nil
+}
+
+// Load reloads the tracked city list from the configured path. A path that
+// does not exist yet is not an error; the in-memory list is left as is.
+func (t *TrackedCities) Load() error {
+	t.mu.RLock()
+	path := t.path
+	t.mu.RUnlock()
+
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return fmt.Errorf("read tracked cities file: %w", err)
+	}
+
+	var cities []string
+	if err := json.Unmarshal(data, &cities); err != nil {
+		return fmt.Errorf("decode tracked cities file: %w", err)
+	}
+
+	t.mu.Lock()
+	t.cities = cities
+	t.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"os"
+	"time"
+)
+
+const (
+	envAPIKey  = "OWM_API_KEY"
+	envBaseURL = "OWM_BASE_URL"
+	envUnits   = "OWM_UNITS"
+	envTimeout = "OWM_TIMEOUT"
+
+	defaultBaseURL = "https://api.openweathermap.org/data/2.5"
+	defaultUnits   = "metric"
+	defaultTimeout = 5 * time.Second
+)
+
+// Config holds the settings required to talk to the OpenWeatherMap API.
+type Config struct {
+	// APIKey is the OpenWeatherMap API key
+	APIKey string
+	// BaseURL is the OpenWeatherMap API base URL
+	BaseURL string
+	// Units controls the unit system used in responses (e.g. "metric")
+	Units string
+	// Timeout is the per-request HTTP client timeout
+	Timeout time.Duration
+}
+
+// LoadConfigFromEnv builds a Config from environment variables, falling
+// back to sane defaults for everything except the API key.
+func LoadConfigFromEnv() Config {
+	cfg := Config{
+		APIKey:  os.Getenv(envAPIKey),
+		BaseURL: defaultBaseURL,
+		Units:   defaultUnits,
+		Timeout: defaultTimeout,
+	}
+
+	if v := os.Getenv(envBaseURL); v != "" {
+		cfg.BaseURL = v
+	}
+
+	if v := os.Getenv(envUnits); v != "" {
+		cfg.Units = v
+	}
+
+	if v := os.Getenv(envTimeout); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Timeout = d
+		}
+	}
+
+	return cfg
+}
+
+// Enabled reports whether enough configuration is present to talk to the
+// upstream API.
+func (c Config) Enabled() bool {
+	return c.APIKey != ""
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/kurushqosimi/rocket_factory/pkg/models"
+)
+
+// owmForecastStepHours is the time resolution of OpenWeatherMap's free
+// forecast endpoint.
+const owmForecastStepHours = 3
+
+// owmMaxForecastEntries is the maximum number of entries OWM's forecast
+// endpoint returns per request.
+const owmMaxForecastEntries = 40
+
+// ForecastProvider is implemented by WeatherProviders that can also return
+// a multi-point forecast. It is a separate, optional interface so callers
+// can type-assert for it rather than every WeatherProvider needing to
+// support forecasts.
+type ForecastProvider interface {
+	// FetchForecast returns forecasted weather for city at roughly 3-hour
+	// resolution, covering at least the next `hours` hours.
+	FetchForecast(ctx context.Context, city string, hours int) ([]*models.Weather, error)
+}
+
+// owmForecastResponse is the subset of OpenWeatherMap's /forecast response
+// that we map onto []*models.Weather.
+type owmForecastResponse struct {
+	List []struct {
+		Dt   int64 `json:"dt"`
+		Main struct {
+			Temp      float64 `json:"temp"`
+			FeelsLike float64 `json:"feels_like"`
+			TempMin   float64 `json:"temp_min"`
+			TempMax   float64 `json:"temp_max"`
+			Pressure  int     `json:"pressure"`
+			Humidity  int     `json:"humidity"`
+		} `json:"main"`
+		Weather []struct {
+			Description string `json:"description"`
+			ID          int    `json:"id"`
+		} `json:"weather"`
+		Wind struct {
+			Speed float64 `json:"speed"`
+			Deg   int     `json:"deg"`
+		} `json:"wind"`
+		Clouds struct {
+			All int `json:"all"`
+		} `json:"clouds"`
+	} `json:"list"`
+	City struct {
+		Coord struct {
+			Lat float64 `json:"lat"`
+			Lon float64 `json:"lon"`
+		} `json:"coord"`
+	} `json:"city"`
+}
+
+// FetchForecast retrieves a forecast for city covering at least the next
+// hours hours from OpenWeatherMap. The request is capped at
+// owmMaxForecastEntries entries (120 hours), so a larger hours value
+// returns only those 120 hours.
+func (p *OpenWeatherMapProvider) FetchForecast(ctx context.Context, city string, hours int) ([]*models.Weather, error) {
+	count := hours / owmForecastStepHours
+	if hours%owmForecastStepHours != 0 {
+		count++
+	}
+	if count < 1 {
+		count = 1
+	}
+	if count > owmMaxForecastEntries {
+		count = owmMaxForecastEntries
+	}
+
+	reqURL := fmt.Sprintf("%s/forecast?%s", p.cfg.BaseURL, url.Values{
+		"q":     {city},
+		"appid": {p.cfg.APIKey},
+		"units": {p.cfg.Units},
+		"cnt":   {fmt.Sprintf("%d", count)},
+	}.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build openweathermap forecast request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute openweathermap forecast request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("openweathermap forecast request for city %q failed with status %d", city, resp.StatusCode)
+	}
+
+	var owmResp owmForecastResponse
+	if err := json.NewDecoder(resp.Body).Decode(&owmResp); err != nil {
+		return nil, fmt.Errorf("decode openweathermap forecast response: %w", err)
+	}
+
+	forecast := make([]*models.Weather, 0, len(owmResp.List))
+	for _, entry := range owmResp.List {
+		weather := &models.Weather{
+			City:        city,
+			Lat:         owmResp.City.Coord.Lat,
+			Lon:         owmResp.City.Coord.Lon,
+			Temperature: entry.Main.Temp,
+			FeelsLike:   entry.Main.FeelsLike,
+			TempMin:     entry.Main.TempMin,
+			TempMax:     entry.Main.TempMax,
+			Pressure:    entry.Main.Pressure,
+			Humidity:    entry.Main.Humidity,
+			WindSpeed:   entry.Wind.Speed,
+			WindDeg:     entry.Wind.Deg,
+			Clouds:      entry.Clouds.All,
+			UpdatedAt:   time.Unix(entry.Dt, 0),
+		}
+
+		if len(entry.Weather) > 0 {
+			weather.Condition = entry.Weather[0].Description
+			weather.ConditionCode = entry.Weather[0].ID
+		}
+
+		forecast = append(forecast, weather)
+	}
+
+	return forecast, nil
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/kurushqosimi/rocket_factory/pkg/models"
+)
+
+// defaultForecastCacheTTL bounds how long a cached forecast is served
+// before FetchForecast is called again.
+const defaultForecastCacheTTL = 30 * time.Minute
+
+// defaultForecastCacheCapacity bounds how many distinct cache keys are
+// kept. The cache key embeds the caller-controlled ?hours= query
+// parameter, so without a capacity bound a client could grow the cache
+// without limit by varying it on every request.
+const defaultForecastCacheCapacity = 1000
+
+// forecastCacheEntry is a cached forecast result.
+type forecastCacheEntry struct {
+	key      string
+	forecast []*models.Weather
+	storedAt time.Time
+}
+
+// ForecastCache is a capacity-bounded, TTL-aware LRU cache of forecast
+// results, keyed by caller (typically "city:hours"), so repeated requests
+// don't all hit upstream.
+type ForecastCache struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	capacity int
+
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+// NewForecastCache creates a ForecastCache with the given TTL and
+// capacity. A non-positive ttl falls back to defaultForecastCacheTTL, and
+// a non-positive capacity falls back to defaultForecastCacheCapacity.
+func NewForecastCache(ttl time.Duration, capacity int) *ForecastCache {
+	if ttl <= 0 {
+		ttl = defaultForecastCacheTTL
+	}
+
+	if capacity <= 0 {
+		capacity = defaultForecastCacheCapacity
+	}
+
+	return &ForecastCache{
+		ttl:      ttl,
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached forecast for key if present and not expired.
+func (c *ForecastCache) Get(key string) ([]*models.Weather, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*forecastCacheEntry)
+	if time.Since(entry.storedAt) > c.ttl {
+		c.removeElement(el)
+		return nil, false
+	}
+
+	c.ll.MoveToFront(el)
+
+	return entry.forecast, true
+}
+
+// Set stores forecast under key, evicting the least recently used entry if
+// the cache is at capacity.
+func (c *ForecastCache) Set(key string, forecast []*models.Weather) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*forecastCacheEntry)
+		entry.forecast = forecast
+		entry.storedAt = time.Now()
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	el := c.ll.PushFront(&forecastCacheEntry{key: key, forecast: forecast, storedAt: time.Now()})
+	c.items[key] = el
+
+	if c.ll.Len() > c.capacity {
+		if oldest := c.ll.Back(); oldest != nil {
+			c.removeElement(oldest)
+		}
+	}
+}
+
+// removeElement removes el from both the list and the index. Callers must
+// hold c.mu.
+func (c *ForecastCache) removeElement(el *list.Element) {
+	c.ll.Remove(el)
+	delete(c.items, el.Value.(*forecastCacheEntry).key)
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/kurushqosimi/rocket_factory/pkg/models"
+)
+
+// OpenWeatherMapProvider implements WeatherProvider against the
+// OpenWeatherMap "current weather" API.
+type OpenWeatherMapProvider struct {
+	cfg    Config
+	client *http.Client
+}
+
+// NewOpenWeatherMapProvider creates a WeatherProvider backed by OpenWeatherMap.
+func NewOpenWeatherMapProvider(cfg Config) *OpenWeatherMapProvider {
+	return &OpenWeatherMapProvider{
+		cfg: cfg,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+		},
+	}
+}
+
+// owmResponse is the subset of the OpenWeatherMap current weather
+// response that we map onto models.Weather.
+type owmResponse struct {
+	Coord struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	} `json:"coord"`
+	Weather []struct {
+		Description string `json:"description"`
+		ID          int    `json:"id"`
+	} `json:"weather"`
+	Main struct {
+		Temp      float64 `json:"temp"`
+		FeelsLike float64 `json:"feels_like"`
+		TempMin   float64 `json:"temp_min"`
+		TempMax   float64 `json:"temp_max"`
+		Pressure  int     `json:"pressure"`
+		Humidity  int     `json:"humidity"`
+	} `json:"main"`
+	Wind struct {
+		Speed float64 `json:"speed"`
+		Deg   int     `json:"deg"`
+	} `json:"wind"`
+	Clouds struct {
+		All int `json:"all"`
+	} `json:"clouds"`
+	Rain struct {
+		OneHour float64 `json:"1h"`
+	} `json:"rain"`
+	Sys struct {
+		Sunrise int64 `json:"sunrise"`
+		Sunset  int64 `json:"sunset"`
+	} `json:"sys"`
+	Name string `json:"name"`
+}
+
+// FetchWeather retrieves current weather for city from OpenWeatherMap.
+func (p *OpenWeatherMapProvider) FetchWeather(ctx context.Context, city string) (*models.Weather, error) {
+	reqURL := fmt.Sprintf("%s/weather?%s", p.cfg.BaseURL, url.Values{
+		"q":     {city},
+		"appid": {p.cfg.APIKey},
+		"units": {p.cfg.Units},
+	}.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build openweathermap request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute openweathermap request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("openweathermap request for city %q failed with status %d", city, resp.StatusCode)
+	}
+
+	var owmResp owmResponse
+	if err := json.NewDecoder(resp.Body).Decode(&owmResp); err != nil {
+		return nil, fmt.Errorf("decode openweathermap response: %w", err)
+	}
+
+	weather := &models.Weather{
+		City:            city,
+		Lat:             owmResp.Coord.Lat,
+		Lon:             owmResp.Coord.Lon,
+		Temperature:     owmResp.Main.Temp,
+		FeelsLike:       owmResp.Main.FeelsLike,
+		TempMin:         owmResp.Main.TempMin,
+		TempMax:         owmResp.Main.TempMax,
+		Pressure:        owmResp.Main.Pressure,
+		Humidity:        owmResp.Main.Humidity,
+		WindSpeed:       owmResp.Wind.Speed,
+		WindDeg:         owmResp.Wind.Deg,
+		Clouds:          owmResp.Clouds.All,
+		PrecipitationMM: owmResp.Rain.OneHour,
+		Sunrise:         time.Unix(owmResp.Sys.Sunrise, 0),
+		Sunset:          time.Unix(owmResp.Sys.Sunset, 0),
+		UpdatedAt:       time.Now(),
+	}
+
+	if len(owmResp.Weather) > 0 {
+		weather.Condition = owmResp.Weather[0].Description
+		weather.ConditionCode = owmResp.Weather[0].ID
+	}
+
+	return weather, nil
+}
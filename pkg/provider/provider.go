@@ -0,0 +1,17 @@
+// Package provider defines an abstraction over upstream weather data
+// sources and provides concrete implementations used to populate
+// WeatherStorage when a city is not available locally.
+package provider
+
+import (
+	"context"
+
+	"github.com/kurushqosimi/rocket_factory/pkg/models"
+)
+
+// WeatherProvider fetches up to date weather data for a city from an
+// upstream source.
+type WeatherProvider interface {
+	// FetchWeather returns current weather data for the given city.
+	FetchWeather(ctx context.Context, city string) (*models.Weather, error)
+}
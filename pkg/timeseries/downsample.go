@@ -0,0 +1,87 @@
+package timeseries
+
+import (
+	"time"
+
+	"github.com/kurushqosimi/rocket_factory/pkg/models"
+)
+
+// Bucket is one downsampled window of temperature samples.
+type Bucket struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Mean  float64   `json:"mean"`
+	Min   float64   `json:"min"`
+	Max   float64   `json:"max"`
+	Count int       `json:"count"`
+	// Filled reports whether this bucket had no samples and was carried
+	// forward from the previous non-empty bucket.
+	Filled bool `json:"filled,omitempty"`
+}
+
+// Downsample buckets samples (assumed sorted ascending by Timestamp) into
+// fixed-width windows of step starting at from, aggregating temperature as
+// mean/min/max per bucket. When carryForward is true, a bucket with no
+// samples copies the previous bucket's values instead of being left empty.
+func Downsample(samples []models.Sample, from, to time.Time, step time.Duration, carryForward bool) []Bucket {
+	if step <= 0 {
+		step = time.Hour
+	}
+
+	var buckets []Bucket
+
+	var lastFilled *Bucket
+
+	idx := 0
+	for bucketStart := from; bucketStart.Before(to); bucketStart = bucketStart.Add(step) {
+		bucketEnd := bucketStart.Add(step)
+		if bucketEnd.After(to) {
+			bucketEnd = to
+		}
+
+		bucket := Bucket{Start: bucketStart, End: bucketEnd}
+
+		var sum float64
+		count := 0
+
+		for idx < len(samples) && samples[idx].Timestamp.Before(bucketEnd) {
+			if samples[idx].Timestamp.Before(bucketStart) {
+				idx++
+				continue
+			}
+
+			temp := samples[idx].Weather.Temperature
+
+			if count == 0 {
+				bucket.Min = temp
+				bucket.Max = temp
+			} else {
+				if temp < bucket.Min {
+					bucket.Min = temp
+				}
+				if temp > bucket.Max {
+					bucket.Max = temp
+				}
+			}
+
+			sum += temp
+			count++
+			idx++
+		}
+
+		if count > 0 {
+			bucket.Count = count
+			bucket.Mean = sum / float64(count)
+			lastFilled = &bucket
+		} else if carryForward && lastFilled != nil {
+			bucket.Mean = lastFilled.Mean
+			bucket.Min = lastFilled.Min
+			bucket.Max = lastFilled.Max
+			bucket.Filled = true
+		}
+
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets
+}
@@ -0,0 +1,85 @@
+package timeseries
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kurushqosimi/rocket_factory/pkg/models"
+)
+
+func sampleAt(temp float64, at time.Time) models.Sample {
+	return models.Sample{Weather: &models.Weather{Temperature: temp}, Timestamp: at}
+}
+
+func TestDownsampleAggregatesMeanMinMax(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(2 * time.Hour)
+
+	samples := []models.Sample{
+		sampleAt(10, from),
+		sampleAt(20, from.Add(10*time.Minute)),
+		sampleAt(15, from.Add(70*time.Minute)),
+	}
+
+	buckets := Downsample(samples, from, to, time.Hour, false)
+	if len(buckets) != 2 {
+		t.Fatalf("len(buckets) = %d, want 2", len(buckets))
+	}
+
+	first := buckets[0]
+	if first.Count != 2 {
+		t.Errorf("first.Count = %d, want 2", first.Count)
+	}
+	if first.Mean != 15 {
+		t.Errorf("first.Mean = %v, want 15", first.Mean)
+	}
+	if first.Min != 10 || first.Max != 20 {
+		t.Errorf("first bucket min/max = %v/%v, want 10/20", first.Min, first.Max)
+	}
+
+	second := buckets[1]
+	if second.Count != 1 || second.Mean != 15 {
+		t.Errorf("second bucket = %+v, want count=1 mean=15", second)
+	}
+}
+
+func TestDownsampleLeavesEmptyBucketsZeroedWithoutCarryForward(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(2 * time.Hour)
+
+	buckets := Downsample([]models.Sample{sampleAt(10, from)}, from, to, time.Hour, false)
+	if len(buckets) != 2 {
+		t.Fatalf("len(buckets) = %d, want 2", len(buckets))
+	}
+
+	if buckets[1].Count != 0 || buckets[1].Filled {
+		t.Errorf("second bucket = %+v, want empty and not filled", buckets[1])
+	}
+}
+
+func TestDownsampleCarriesForwardEmptyBuckets(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(2 * time.Hour)
+
+	buckets := Downsample([]models.Sample{sampleAt(10, from)}, from, to, time.Hour, true)
+	if len(buckets) != 2 {
+		t.Fatalf("len(buckets) = %d, want 2", len(buckets))
+	}
+
+	if !buckets[1].Filled {
+		t.Error("second bucket should be marked as filled")
+	}
+	if buckets[1].Mean != 10 {
+		t.Errorf("second.Mean = %v, want 10 (carried forward)", buckets[1].Mean)
+	}
+}
+
+func TestDownsampleClampsNonPositiveStepToDefault(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(2 * time.Hour)
+
+	buckets := Downsample(nil, from, to, 0, false)
+	if len(buckets) != 2 {
+		t.Fatalf("len(buckets) = %d, want 2 (a non-positive step should default to 1h)", len(buckets))
+	}
+}
@@ -0,0 +1,107 @@
+//go:build influxdb
+
+package timeseries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api"
+
+	"github.com/kurushqosimi/rocket_factory/pkg/models"
+)
+
+const measurement = "weather"
+
+// InfluxStore persists samples to an InfluxDB bucket. It only round-trips
+// the fields needed to reconstruct history (temperature), since Influx is
+// used here purely as a numeric time-series backend rather than a full
+// object store.
+type InfluxStore struct {
+	client   influxdb2.Client
+	writeAPI api.WriteAPIBlocking
+	queryAPI api.QueryAPI
+	org      string
+	bucket   string
+}
+
+// NewInfluxStore creates an InfluxStore writing to org/bucket on the server at url.
+func NewInfluxStore(url, token, org, bucket string) *InfluxStore {
+	client := influxdb2.NewClient(url, token)
+
+	return &InfluxStore{
+		client:   client,
+		writeAPI: client.WriteAPIBlocking(org, bucket),
+		queryAPI: client.QueryAPI(org),
+		org:      org,
+		bucket:   bucket,
+	}
+}
+
+// Append records sample for city.
+func (s *InfluxStore) Append(city string, sample models.Sample) error {
+	point := influxdb2.NewPoint(
+		measurement,
+		map[string]string{"city": city},
+		map[string]interface{}{
+			"temperature": sample.Weather.Temperature,
+			"humidity":    sample.Weather.Humidity,
+			"pressure":    sample.Weather.Pressure,
+		},
+		sample.Timestamp,
+	)
+
+	if err := s.writeAPI.WritePoint(context.Background(), point); err != nil {
+		return fmt.Errorf("write influx point: %w", err)
+	}
+
+	return nil
+}
+
+// Query returns every sample recorded for city within [from, to), in time
+// order. Flux's range() excludes its stop time, so a sample stamped exactly
+// at to is not returned.
+func (s *InfluxStore) Query(city string, from, to time.Time) ([]models.Sample, error) {
+	flux := fmt.Sprintf(`
+		from(bucket: %q)
+			|> range(start: %s, stop: %s)
+			|> filter(fn: (r) => r._measurement == %q and r.city == %q and r._field == "temperature")
+			|> sort(columns: ["_time"])
+	`, s.bucket, from.Format(time.RFC3339), to.Format(time.RFC3339), measurement, city)
+
+	result, err := s.queryAPI.Query(context.Background(), flux)
+	if err != nil {
+		return nil, fmt.Errorf("query influx: %w", err)
+	}
+	defer result.Close()
+
+	var samples []models.Sample
+	for result.Next() {
+		record := result.Record()
+
+		temperature, _ := record.Value().(float64)
+
+		samples = append(samples, models.Sample{
+			Weather: &models.Weather{
+				City:        city,
+				Temperature: temperature,
+				UpdatedAt:   record.Time(),
+			},
+			Timestamp: record.Time(),
+		})
+	}
+
+	if result.Err() != nil {
+		return nil, fmt.Errorf("iterate influx results: %w", result.Err())
+	}
+
+	return samples, nil
+}
+
+// Close closes the underlying Influx client.
+func (s *InfluxStore) Close() error {
+	s.client.Close()
+	return nil
+}
@@ -0,0 +1,75 @@
+// Package timeseries provides models.TimeSeriesStore implementations: an
+// in-memory ring buffer by default, plus optional SQLite and InfluxDB
+// backends behind build tags for deployments that need durable history.
+package timeseries
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kurushqosimi/rocket_factory/pkg/models"
+)
+
+// DefaultCapacity is the number of samples kept per city when none is configured.
+const DefaultCapacity = 1000
+
+// RingStore is an in-memory, fixed-capacity, per-city history of samples.
+// Once a city's history reaches capacity, the oldest sample is dropped as
+// new ones arrive.
+type RingStore struct {
+	mu       sync.RWMutex
+	capacity int
+	series   map[string][]models.Sample
+}
+
+// NewRingStore creates a RingStore keeping up to capacity samples per city.
+func NewRingStore(capacity int) *RingStore {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+
+	return &RingStore{
+		capacity: capacity,
+		series:   make(map[string][]models.Sample),
+	}
+}
+
+// Append records sample for city, evicting the oldest sample if the city's
+// history is at capacity.
+func (r *RingStore) Append(city string, sample models.Sample) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	samples := append(r.series[city], sample)
+	if len(samples) > r.capacity {
+		samples = samples[len(samples)-r.capacity:]
+	}
+
+	r.series[city] = samples
+
+	return nil
+}
+
+// Query returns every sample recorded for city within [from, to], in time order.
+func (r *RingStore) Query(city string, from, to time.Time) ([]models.Sample, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	samples := r.series[city]
+
+	result := make([]models.Sample, 0, len(samples))
+	for _, sample := range samples {
+		if sample.Timestamp.Before(from) || sample.Timestamp.After(to) {
+			continue
+		}
+
+		result = append(result, sample)
+	}
+
+	return result, nil
+}
+
+// Close is a no-op; RingStore holds no external resources.
+func (r *RingStore) Close() error {
+	return nil
+}
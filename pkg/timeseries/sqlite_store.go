@@ -0,0 +1,112 @@
+//go:build sqlite
+
+package timeseries
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/kurushqosimi/rocket_factory/pkg/models"
+)
+
+// SQLiteStore persists samples to a SQLite database, for deployments that
+// want durable history without running a separate time-series database.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (creating if necessary) a SQLite database at path
+// and ensures its schema exists.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite database: %w", err)
+	}
+
+	const schema = `
+		CREATE TABLE IF NOT EXISTS weather_samples (
+			city      TEXT NOT NULL,
+			timestamp INTEGER NOT NULL,
+			data      TEXT NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_weather_samples_city_timestamp
+			ON weather_samples (city, timestamp);
+	`
+
+	if _, err := db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("create sqlite schema: %w", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+// Append records sample for city.
+func (s *SQLiteStore) Append(city string, sample models.Sample) error {
+	data, err := json.Marshal(sample.Weather)
+	if err != nil {
+		return fmt.Errorf("encode weather sample: %w", err)
+	}
+
+	_, err = s.db.Exec(
+		`INSERT INTO weather_samples (city, timestamp, data) VALUES (?, ?, ?)`,
+		city, sample.Timestamp.Unix(), data,
+	)
+	if err != nil {
+		return fmt.Errorf("insert weather sample: %w", err)
+	}
+
+	return nil
+}
+
+// Query returns every sample recorded for city within [from, to], in time order.
+func (s *SQLiteStore) Query(city string, from, to time.Time) ([]models.Sample, error) {
+	rows, err := s.db.Query(
+		`SELECT timestamp, data FROM weather_samples
+		 WHERE city = ? AND timestamp BETWEEN ? AND ?
+		 ORDER BY timestamp ASC`,
+		city, from.Unix(), to.Unix(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query weather samples: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var samples []models.Sample
+	for rows.Next() {
+		var (
+			ts   int64
+			data []byte
+		)
+
+		if err := rows.Scan(&ts, &data); err != nil {
+			return nil, fmt.Errorf("scan weather sample: %w", err)
+		}
+
+		var weather models.Weather
+		if err := json.Unmarshal(data, &weather); err != nil {
+			return nil, fmt.Errorf("decode weather sample: %w", err)
+		}
+
+		samples = append(samples, models.Sample{
+			Weather:   &weather,
+			Timestamp: time.Unix(ts, 0),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate weather samples: %w", err)
+	}
+
+	return samples, nil
+}
+
+// Close closes the underlying database handle.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}